@@ -0,0 +1,5 @@
+// Package eviction provides pluggable cache eviction policies for
+// machinery.Cache[K, V]. Each policy type implements the method set of
+// machinery.EvictionPolicy[K] (OnAccess, OnInsert, OnDelete, Evict)
+// structurally, so this package has no dependency on machinery itself.
+package eviction
@@ -0,0 +1,116 @@
+package eviction
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// lfuEntry is one key's bookkeeping in the LFU min-heap.
+type lfuEntry[K comparable] struct {
+	key   K
+	freq  int
+	index int
+}
+
+// lfuHeap is a container/heap.Interface ordering entries by ascending
+// frequency, so the least frequently used entry always sits at index 0.
+type lfuHeap[K comparable] []*lfuEntry[K]
+
+func (h lfuHeap[K]) Len() int           { return len(h) }
+func (h lfuHeap[K]) Less(i, j int) bool { return h[i].freq < h[j].freq }
+func (h lfuHeap[K]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *lfuHeap[K]) Push(x interface{}) {
+	entry := x.(*lfuEntry[K])
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *lfuHeap[K]) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return entry
+}
+
+// LFU is a least-frequently-used eviction policy backed by a min-heap of
+// per-key frequency counters. Its own mutex guards the heap and index so
+// it can be driven from Cache.Get, which only holds Cache's RWMutex for
+// reading.
+type LFU[K comparable] struct {
+	mu      sync.Mutex
+	heap    lfuHeap[K]
+	entries map[K]*lfuEntry[K]
+}
+
+// NewLFU returns a new, empty LFU policy.
+func NewLFU[K comparable]() *LFU[K] {
+	return &LFU[K]{
+		entries: make(map[K]*lfuEntry[K]),
+	}
+}
+
+// OnAccess increments key's frequency counter.
+func (l *LFU[K]) OnAccess(key K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, found := l.entries[key]
+	if !found {
+		return
+	}
+	entry.freq++
+	heap.Fix(&l.heap, entry.index)
+}
+
+// OnInsert starts tracking a newly inserted key at frequency 1.
+func (l *LFU[K]) OnInsert(key K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if entry, found := l.entries[key]; found {
+		entry.freq++
+		heap.Fix(&l.heap, entry.index)
+		return
+	}
+
+	entry := &lfuEntry[K]{key: key, freq: 1}
+	l.entries[key] = entry
+	heap.Push(&l.heap, entry)
+}
+
+// OnDelete removes key from the policy's bookkeeping.
+func (l *LFU[K]) OnDelete(key K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, found := l.entries[key]
+	if !found {
+		return
+	}
+	heap.Remove(&l.heap, entry.index)
+	delete(l.entries, key)
+}
+
+// Evict returns the least frequently used key, and false if the policy
+// isn't tracking any keys. LFU doesn't filter admission, so the candidate
+// about to be inserted plays no part in the decision.
+func (l *LFU[K]) Evict(candidate K) (K, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.heap.Len() == 0 {
+		var zero K
+		return zero, false
+	}
+
+	entry := heap.Pop(&l.heap).(*lfuEntry[K])
+	delete(l.entries, entry.key)
+	return entry.key, true
+}
@@ -0,0 +1,73 @@
+package eviction
+
+import (
+	"container/list"
+	"sync"
+)
+
+// LRU is a least-recently-used eviction policy backed by a doubly linked
+// list: every access moves a key to the front, so the back of the list is
+// always the least recently used key and Evict is O(1). Its own mutex
+// guards the list and index so it can be driven from Cache.Get, which
+// only holds Cache's RWMutex for reading.
+type LRU[K comparable] struct {
+	mu    sync.Mutex
+	order *list.List
+	nodes map[K]*list.Element
+}
+
+// NewLRU returns a new, empty LRU policy.
+func NewLRU[K comparable]() *LRU[K] {
+	return &LRU[K]{
+		order: list.New(),
+		nodes: make(map[K]*list.Element),
+	}
+}
+
+// OnAccess moves key to the front of the list, marking it most recently
+// used.
+func (l *LRU[K]) OnAccess(key K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if node, found := l.nodes[key]; found {
+		l.order.MoveToFront(node)
+		return
+	}
+	l.nodes[key] = l.order.PushFront(key)
+}
+
+// OnInsert records a newly inserted key as most recently used.
+func (l *LRU[K]) OnInsert(key K) {
+	l.OnAccess(key)
+}
+
+// OnDelete removes key from the policy's bookkeeping.
+func (l *LRU[K]) OnDelete(key K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if node, found := l.nodes[key]; found {
+		l.order.Remove(node)
+		delete(l.nodes, key)
+	}
+}
+
+// Evict returns the least recently used key, and false if the policy
+// isn't tracking any keys. LRU doesn't filter admission, so the candidate
+// about to be inserted plays no part in the decision.
+func (l *LRU[K]) Evict(candidate K) (K, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	back := l.order.Back()
+	if back == nil {
+		var zero K
+		return zero, false
+	}
+
+	key := back.Value.(K)
+	l.order.Remove(back)
+	delete(l.nodes, key)
+	return key, true
+}
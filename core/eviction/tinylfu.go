@@ -0,0 +1,265 @@
+package eviction
+
+import (
+	"container/list"
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
+
+const (
+	sketchDepth      = 4
+	counterMax       = 15 // 4-bit saturating counter
+	protectedPercent = 80
+)
+
+// countMinSketch is a small, fixed-width frequency sketch used as the
+// TinyLFU admission filter. Each of its sketchDepth rows hashes a key with
+// a different seed to a 4-bit saturating counter; Estimate returns the
+// minimum count seen across all rows, which over-estimates frequency only
+// in the case of hash collisions across every row at once. Keys are
+// hashed via their fmt.Sprintf("%v", ...) form so the sketch works for any
+// comparable key type, not just string.
+type countMinSketch struct {
+	width     uint32
+	counters  [][]byte // counters[row] packs two 4-bit counters per byte
+	additions int
+}
+
+func newCountMinSketch(width uint32) *countMinSketch {
+	// round width up to a power of two so masking works
+	w := uint32(1)
+	for w < width {
+		w <<= 1
+	}
+
+	rows := make([][]byte, sketchDepth)
+	for i := range rows {
+		rows[i] = make([]byte, (w+1)/2)
+	}
+
+	return &countMinSketch{width: w, counters: rows}
+}
+
+func (s *countMinSketch) index(row int, key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte{byte(row)})
+	h.Write([]byte(key))
+	return h.Sum32() & (s.width - 1)
+}
+
+func (s *countMinSketch) get(row int, idx uint32) byte {
+	b := s.counters[row][idx/2]
+	if idx%2 == 0 {
+		return b & 0x0F
+	}
+	return b >> 4
+}
+
+func (s *countMinSketch) set(row int, idx uint32, v byte) {
+	b := s.counters[row][idx/2]
+	if idx%2 == 0 {
+		s.counters[row][idx/2] = (b & 0xF0) | (v & 0x0F)
+	} else {
+		s.counters[row][idx/2] = (b & 0x0F) | (v << 4)
+	}
+}
+
+// Add increments key's counter in every row, saturating at counterMax.
+func (s *countMinSketch) Add(key string) {
+	for row := 0; row < sketchDepth; row++ {
+		idx := s.index(row, key)
+		if v := s.get(row, idx); v < counterMax {
+			s.set(row, idx, v+1)
+		}
+	}
+	s.additions++
+}
+
+// Estimate returns key's estimated frequency, the minimum counter value
+// across all rows.
+func (s *countMinSketch) Estimate(key string) byte {
+	min := byte(counterMax)
+	for row := 0; row < sketchDepth; row++ {
+		if v := s.get(row, s.index(row, key)); v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+// Age halves every counter, so the sketch tracks recent frequency rather
+// than accumulating forever.
+func (s *countMinSketch) Age() {
+	for row := range s.counters {
+		for i, b := range s.counters[row] {
+			hi := (b >> 4) / 2
+			lo := (b & 0x0F) / 2
+			s.counters[row][i] = (hi << 4) | lo
+		}
+	}
+	s.additions = 0
+}
+
+// TinyLFU is an admission-filtered, segmented-LRU eviction policy: a
+// count-min sketch estimates how frequently each key has been seen, and
+// that estimate decides whether a newly inserted key is worth admitting
+// over the existing probationary victim it would otherwise evict. A key
+// that is accessed while in the probationary segment is promoted to the
+// protected segment, which is sized to hold the bulk (~80%) of the
+// policy's keys.
+type TinyLFU[K comparable] struct {
+	mu              sync.Mutex
+	sketch          *countMinSketch
+	windowLimit     int
+	protectedCap    int
+	probationaryCap int
+	protected       *list.List
+	probationary    *list.List
+	nodes           map[K]*list.Element
+	segments        map[K]*list.List
+}
+
+// NewTinyLFU returns a new TinyLFU policy sized for roughly capacity
+// items, split 80/20 between the protected and probationary segments.
+func NewTinyLFU[K comparable](capacity int) *TinyLFU[K] {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	protectedCap := capacity * protectedPercent / 100
+	if protectedCap < 1 {
+		protectedCap = 1
+	}
+	probationaryCap := capacity - protectedCap
+	if probationaryCap < 1 {
+		probationaryCap = 1
+	}
+
+	return &TinyLFU[K]{
+		sketch:          newCountMinSketch(uint32(capacity * 8)),
+		windowLimit:     capacity,
+		protectedCap:    protectedCap,
+		probationaryCap: probationaryCap,
+		protected:       list.New(),
+		probationary:    list.New(),
+		nodes:           make(map[K]*list.Element),
+		segments:        make(map[K]*list.List),
+	}
+}
+
+func sketchKey[K comparable](key K) string {
+	return fmt.Sprintf("%v", key)
+}
+
+func (t *TinyLFU[K]) touchSketch(key K) {
+	t.sketch.Add(sketchKey(key))
+	if t.sketch.additions >= t.windowLimit {
+		t.sketch.Age()
+	}
+}
+
+// OnAccess records another observation of key and, if key is currently in
+// the probationary segment, promotes it to protected, demoting the
+// protected segment's LRU tail back to probationary if that pushes
+// protected over its capacity.
+func (t *TinyLFU[K]) OnAccess(key K) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.touchSketch(key)
+
+	node, found := t.nodes[key]
+	if !found {
+		return
+	}
+
+	if t.segments[key] == t.protected {
+		t.protected.MoveToFront(node)
+		return
+	}
+
+	t.probationary.Remove(node)
+	delete(t.segments, key)
+	t.nodes[key] = t.protected.PushFront(key)
+	t.segments[key] = t.protected
+
+	if t.protected.Len() > t.protectedCap {
+		demoted := t.protected.Back()
+		demotedKey := demoted.Value.(K)
+		t.protected.Remove(demoted)
+		t.nodes[demotedKey] = t.probationary.PushFront(demotedKey)
+		t.segments[demotedKey] = t.probationary
+	}
+}
+
+// OnInsert admits a newly seen key into the probationary segment.
+func (t *TinyLFU[K]) OnInsert(key K) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.touchSketch(key)
+
+	if _, found := t.nodes[key]; found {
+		return
+	}
+
+	t.nodes[key] = t.probationary.PushFront(key)
+	t.segments[key] = t.probationary
+}
+
+// OnDelete removes key from whichever segment currently holds it.
+func (t *TinyLFU[K]) OnDelete(key K) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	node, found := t.nodes[key]
+	if !found {
+		return
+	}
+
+	t.segments[key].Remove(node)
+	delete(t.nodes, key)
+	delete(t.segments, key)
+}
+
+// Evict returns the key to give up to make room for candidate, the key
+// about to be inserted. The probationary segment's LRU tail is the default
+// victim, but if candidate is estimated to be seen no more often than that
+// victim, TinyLFU rejects candidate instead: it returns candidate's own
+// key, leaving the probationary segment untouched. The caller (Cache)
+// recognizes a returned key equal to candidate as a rejection and must
+// skip admitting candidate altogether, not treat it as an ordinary
+// eviction of a same-named entry.
+func (t *TinyLFU[K]) Evict(candidate K) (K, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.probationary.Len() > 0 {
+		victim := t.probationary.Back()
+		victimKey := victim.Value.(K)
+
+		victimFreq := t.sketch.Estimate(sketchKey(victimKey))
+		candidateFreq := t.sketch.Estimate(sketchKey(candidate))
+		if candidateFreq <= victimFreq {
+			return candidate, true
+		}
+
+		t.probationary.Remove(victim)
+		delete(t.nodes, victimKey)
+		delete(t.segments, victimKey)
+		return victimKey, true
+	}
+
+	if t.protected.Len() > 0 {
+		victim := t.protected.Back()
+		key := victim.Value.(K)
+		t.protected.Remove(victim)
+		delete(t.nodes, key)
+		delete(t.segments, key)
+		return key, true
+	}
+
+	var zero K
+	return zero, false
+}
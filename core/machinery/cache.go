@@ -9,26 +9,37 @@ import (
 	"../models"
 )
 
-// Cache represents the in memory key value store
-type Cache struct {
+// Cache represents the in memory key value store, generic over a
+// comparable key type K and an arbitrary value type V. Storing V directly
+// on models.Item[V] instead of interface{} gives callers compile-time type
+// safety and avoids the boxing allocation the original interface{}-based
+// Cache paid on every Get. The original API is preserved, unchanged, as
+// veloce/legacy.Cache for callers that can't take the type parameters yet.
+type Cache[K comparable, V any] struct {
 	DefaultExpiration time.Duration
-	Items             map[string]models.Item
+	Items             map[K]models.Item[V]
 	mu                sync.RWMutex
-	onEvicted         func(string, interface{})
-	GarbageCollector  *GarbageCollector
+	onEvicted         func(K, V)
+	GarbageCollector  *GarbageCollector[K, V]
+	// MaxItems bounds the cache to roughly this many items. Once reached,
+	// Set consults EvictionPolicy to make room for a new key. A value of
+	// 0 (the default) means unbounded: items only leave via TTL expiry or
+	// explicit Delete.
+	MaxItems       int
+	evictionPolicy EvictionPolicy[K]
 }
 
 // Represents a key value pair
-type keyAndValue struct {
-	key   string
-	value interface{}
+type keyAndValue[K comparable, V any] struct {
+	key   K
+	value V
 }
 
 // Set add an item to the cache, replacing any existing item.
 // If the duration is 0 (DefaultExpiration), the cache's default
 // expiration time is used. If it is -1 (NoExpiration), the item
 // never expired
-func (c *Cache) Set(key string, value interface{}, duration time.Duration) {
+func (c *Cache[K, V]) Set(key K, value V, duration time.Duration) {
 	var expiration int64
 	if duration == globals.DefaultExpiration {
 		duration = c.DefaultExpiration
@@ -39,14 +50,24 @@ func (c *Cache) Set(key string, value interface{}, duration time.Duration) {
 	}
 
 	c.mu.Lock()
-	c.Items[key] = models.Item{
-		Object:     value,
-		Expiration: expiration,
+	evictKey, evictValue, evicted, admit := c.evictIfNeeded(key)
+	if admit {
+		c.Items[key] = models.Item[V]{
+			Object:     value,
+			Expiration: expiration,
+		}
+		if c.evictionPolicy != nil {
+			c.evictionPolicy.OnInsert(key)
+		}
 	}
 	c.mu.Unlock()
+
+	if evicted {
+		c.onEvicted(evictKey, evictValue)
+	}
 }
 
-func (c *Cache) set(key string, value interface{}, duration time.Duration) {
+func (c *Cache[K, V]) set(key K, value V, duration time.Duration) (K, V, bool, bool) {
 	var expiration int64
 	if duration == globals.DefaultExpiration {
 		duration = c.DefaultExpiration
@@ -56,135 +77,239 @@ func (c *Cache) set(key string, value interface{}, duration time.Duration) {
 		expiration = time.Now().Add(duration).UnixNano()
 	}
 
-	c.Items[key] = models.Item{
-		Object:     value,
-		Expiration: expiration,
+	evictKey, evictValue, evicted, admit := c.evictIfNeeded(key)
+	if admit {
+		c.Items[key] = models.Item[V]{
+			Object:     value,
+			Expiration: expiration,
+		}
+		if c.evictionPolicy != nil {
+			c.evictionPolicy.OnInsert(key)
+		}
+	}
+	return evictKey, evictValue, evicted, admit
+}
+
+// evictIfNeeded consults the eviction policy for a key to give up when
+// inserting key would grow the cache past MaxItems, and reports whether it
+// evicted one along with its key and value, and whether key itself should
+// be admitted into the cache at all. The caller must hold c.mu, and must
+// defer invoking onEvicted with the returned key/value until after
+// releasing it, the same way delete and DeleteExpired do: onEvicted is
+// arbitrary caller code, and calling it while c.mu is held would deadlock
+// any callback that itself touches the cache. admit is false only when an
+// admission-filtered policy (TinyLFU) rejected key outright, in which case
+// the caller must skip the insert entirely; every other path admits key,
+// evicting a victim first when the policy named one. evicted is false
+// when the cache is unbounded, key already has an entry (so the insert
+// won't grow the cache), the policy has nothing left to evict, or no
+// onEvicted callback is set to receive the evicted value.
+func (c *Cache[K, V]) evictIfNeeded(key K) (victimKey K, victimValue V, evicted bool, admit bool) {
+	var zeroKey K
+	var zeroValue V
+
+	if c.evictionPolicy == nil || c.MaxItems <= 0 {
+		return zeroKey, zeroValue, false, true
+	}
+
+	if _, exists := c.Items[key]; exists {
+		return zeroKey, zeroValue, false, true
 	}
+
+	if len(c.Items) < c.MaxItems {
+		return zeroKey, zeroValue, false, true
+	}
+
+	evictKey, ok := c.evictionPolicy.Evict(key)
+	if !ok {
+		return zeroKey, zeroValue, false, true
+	}
+
+	if evictKey == key {
+		// The policy rejected key itself (TinyLFU's admission filter):
+		// nothing to evict, and key must not be inserted either.
+		return zeroKey, zeroValue, false, false
+	}
+
+	if c.onEvicted == nil {
+		delete(c.Items, evictKey)
+		return zeroKey, zeroValue, false, true
+	}
+
+	oldItem, found := c.Items[evictKey]
+	delete(c.Items, evictKey)
+	if !found {
+		return zeroKey, zeroValue, false, true
+	}
+
+	return evictKey, oldItem.Object, true, true
 }
 
 // SetDefault adds an item to the cache only if an item doesn't
 // already exist for the given key, or if the existing item has
 // expired. Returns an error otherwise.
-func (c *Cache) SetDefault(key string, value interface{}) {
+func (c *Cache[K, V]) SetDefault(key K, value V) {
 	c.Set(key, value, globals.DefaultExpiration)
 }
 
 // Add an item to the cache only if an item doesn't alrady exist
 // for the given key, or if the existing item has expired. Returns
 // an error otherwise.
-func (c *Cache) Add(key string, value interface{}, duration time.Duration) error {
+func (c *Cache[K, V]) Add(key K, value V, duration time.Duration) error {
 	c.mu.Lock()
 	_, found := c.get(key)
 
 	if found {
 		c.mu.Unlock()
-		return fmt.Errorf("Item %s already exists", key)
+		return fmt.Errorf("Item %v already exists", key)
 	}
 
-	c.set(key, value, duration)
+	evictKey, evictValue, evicted, _ := c.set(key, value, duration)
 	c.mu.Unlock()
 
+	if evicted {
+		c.onEvicted(evictKey, evictValue)
+	}
+
 	return nil
 }
 
 // Replace sets a new value for the cache key only if it already exists,
 // and the existing item hasn't expired. Returns an error otherwise.
-func (c *Cache) Replace(key string, value interface{}, duration time.Duration) error {
+func (c *Cache[K, V]) Replace(key K, value V, duration time.Duration) error {
 	c.mu.Lock()
 	_, found := c.get(key)
 
 	if !found {
 		c.mu.Unlock()
-		return fmt.Errorf("Item %s doesn't exist", key)
+		return fmt.Errorf("Item %v doesn't exist", key)
 	}
 
-	c.set(key, value, duration)
+	evictKey, evictValue, evicted, _ := c.set(key, value, duration)
 	c.mu.Unlock()
+
+	if evicted {
+		c.onEvicted(evictKey, evictValue)
+	}
 	return nil
 }
 
-// Get an item from the cache. Returns the item or nil, and a bool
-// indicating whether the key was found.
-func (c *Cache) Get(key string) (interface{}, bool) {
+// Get an item from the cache. Returns the item (or its zero value) and a
+// bool indicating whether the key was found.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
 	c.mu.RLock()
 	item, found := c.Items[key]
 
 	if !found {
 		c.mu.RUnlock()
-		return nil, false
+		var zero V
+		return zero, false
 	}
 
 	if item.Expiration > 0 {
 		if time.Now().UnixNano() > item.Expiration {
 			c.mu.RUnlock()
-			return nil, false
+			var zero V
+			return zero, false
 		}
 	}
 
+	if c.evictionPolicy != nil {
+		c.evictionPolicy.OnAccess(key)
+	}
+
 	c.mu.RUnlock()
 	return item.Object, true
 }
 
 // GetWithExpiration returns an item and its expiration time from the cache.
-// It returns the item or nil, the expiration time if one is set and a bool
-// indicating whether the key was found.
-func (c *Cache) GetWithExpiration(key string) (interface{}, time.Time, bool) {
+// It returns the item (or its zero value), the expiration time if one is
+// set and a bool indicating whether the key was found.
+func (c *Cache[K, V]) GetWithExpiration(key K) (V, time.Time, bool) {
 	c.mu.RLock()
 	item, found := c.Items[key]
 
 	if !found {
 		c.mu.RUnlock()
-		return nil, time.Time{}, false
+		var zero V
+		return zero, time.Time{}, false
 	}
 
 	if item.Expiration > 0 {
 		if time.Now().UnixNano() > item.Expiration {
 			c.mu.RUnlock()
-			return nil, time.Time{}, false
+			var zero V
+			return zero, time.Time{}, false
 		}
 
 		// returns the item and the expiration time
+		if c.evictionPolicy != nil {
+			c.evictionPolicy.OnAccess(key)
+		}
 		c.mu.RUnlock()
 		return item.Object, time.Unix(0, item.Expiration), true
 	}
 
 	// Expiration is <= 0 means no expiration is set, therefore return
 	// the item and a zero as time
+	if c.evictionPolicy != nil {
+		c.evictionPolicy.OnAccess(key)
+	}
 	c.mu.RUnlock()
 	return item.Object, time.Time{}, true
 }
 
-func (c *Cache) get(key string) (interface{}, bool) {
+func (c *Cache[K, V]) get(key K) (V, bool) {
 	item, found := c.Items[key]
 
 	if !found {
-		return nil, false
+		var zero V
+		return zero, false
 	}
 
 	if item.Expiration > 0 {
 		if time.Now().UnixNano() > item.Expiration {
-			return nil, false
+			var zero V
+			return zero, false
 		}
 	}
 
+	if c.evictionPolicy != nil {
+		c.evictionPolicy.OnAccess(key)
+	}
+
 	return item.Object, true
 }
 
-func (c *Cache) delete(key string) (interface{}, bool) {
-	if c.onEvicted != nil {
-		if item, found := c.Items[key]; found {
-			delete(c.Items, key)
-			return item.Object, true
-		}
+// delete removes key from the cache and, when an onEvicted callback is
+// set, reports whether it was actually present and, if so, its value.
+// When no onEvicted callback is set, the caller has no use for the value,
+// so delete takes a fast path that skips the lookup entirely, just removes
+// the key, and reports false whether or not the key was present.
+func (c *Cache[K, V]) delete(key K) (V, bool) {
+	if c.evictionPolicy != nil {
+		c.evictionPolicy.OnDelete(key)
+	}
+
+	if c.onEvicted == nil {
+		delete(c.Items, key)
+		var zero V
+		return zero, false
+	}
+
+	item, found := c.Items[key]
+	if !found {
+		var zero V
+		return zero, false
 	}
 
 	delete(c.Items, key)
-	return nil, false
+	return item.Object, true
 }
 
 // Delete an item from the cache. Does nothing if the key is not
 // in the cache.
-func (c *Cache) Delete(key string) {
+func (c *Cache[K, V]) Delete(key K) {
 	c.mu.Lock()
 	item, evicted := c.delete(key)
 	c.mu.Unlock()
@@ -194,8 +319,8 @@ func (c *Cache) Delete(key string) {
 }
 
 // DeleteExpired deletes expired items from the cache.
-func (c *Cache) DeleteExpired() {
-	var evictedItems []keyAndValue
+func (c *Cache[K, V]) DeleteExpired() {
+	var evictedItems []keyAndValue[K, V]
 	now := time.Now().UnixNano()
 
 	c.mu.Lock()
@@ -203,7 +328,7 @@ func (c *Cache) DeleteExpired() {
 		if value.Expiration > 0 && now > value.Expiration {
 			oldValue, evicted := c.delete(key)
 			if evicted {
-				evictedItems = append(evictedItems, keyAndValue{key, oldValue})
+				evictedItems = append(evictedItems, keyAndValue[K, V]{key, oldValue})
 			}
 		}
 	}
@@ -216,18 +341,27 @@ func (c *Cache) DeleteExpired() {
 
 // OnEvicted sets an function that is called with the key and value when an
 // item is evicted from the cache.
-func (c *Cache) OnEvicted(f func(string, interface{})) {
+func (c *Cache[K, V]) OnEvicted(f func(K, V)) {
 	c.mu.Lock()
 	c.onEvicted = f
 	c.mu.Unlock()
 }
 
+// SetEvictionPolicy sets the policy consulted to make room once the cache
+// reaches MaxItems. Passing nil disables eviction, leaving the cache
+// unbounded regardless of MaxItems.
+func (c *Cache[K, V]) SetEvictionPolicy(policy EvictionPolicy[K]) {
+	c.mu.Lock()
+	c.evictionPolicy = policy
+	c.mu.Unlock()
+}
+
 // GetItems copies all unexpired items in the cache into a new map and return it.
-func (c *Cache) GetItems() map[string]models.Item {
+func (c *Cache[K, V]) GetItems() map[K]models.Item[V] {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	cacheMap := make(map[string]models.Item, len(c.Items))
+	cacheMap := make(map[K]models.Item[V], len(c.Items))
 	now := time.Now().UnixNano()
 
 	for key, value := range c.Items {
@@ -243,7 +377,7 @@ func (c *Cache) GetItems() map[string]models.Item {
 
 // ItemCount returns the count of all items in the cache including the expired
 // items.
-func (c *Cache) ItemCount() int {
+func (c *Cache[K, V]) ItemCount() int {
 	c.mu.RLock()
 	itemCount := len(c.Items)
 	c.mu.RUnlock()
@@ -251,8 +385,8 @@ func (c *Cache) ItemCount() int {
 }
 
 // Flush all items from the cache.
-func (c *Cache) Flush() {
+func (c *Cache[K, V]) Flush() {
 	c.mu.Lock()
-	c.Items = map[string]models.Item{}
+	c.Items = map[K]models.Item[V]{}
 	c.mu.Unlock()
 }
@@ -0,0 +1,138 @@
+package machinery
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"../eviction"
+	"../globals"
+	"../models"
+)
+
+func newTestCache() *Cache[string, int] {
+	return &Cache[string, int]{
+		DefaultExpiration: globals.NoExpiration,
+		Items:             make(map[string]models.Item[int]),
+	}
+}
+
+func TestDeleteMissingKey(t *testing.T) {
+	c := newTestCache()
+
+	c.Delete("nope")
+
+	if _, found := c.Items["nope"]; found {
+		t.Fatalf("expected no item under key \"nope\"")
+	}
+}
+
+func TestDeleteWithCallback(t *testing.T) {
+	c := newTestCache()
+	c.Items["foo"] = models.Item[int]{Object: 42}
+
+	var gotKey string
+	var gotValue int
+	c.OnEvicted(func(key string, value int) {
+		gotKey = key
+		gotValue = value
+	})
+
+	c.Delete("foo")
+
+	if gotKey != "foo" || gotValue != 42 {
+		t.Fatalf("expected callback with (\"foo\", 42), got (%q, %v)", gotKey, gotValue)
+	}
+	if _, found := c.Items["foo"]; found {
+		t.Fatalf("expected \"foo\" to be removed from the cache")
+	}
+}
+
+func TestDeleteWithoutCallback(t *testing.T) {
+	c := newTestCache()
+	c.Items["foo"] = models.Item[int]{Object: 42}
+
+	c.Delete("foo")
+
+	if _, found := c.Items["foo"]; found {
+		t.Fatalf("expected \"foo\" to be removed from the cache")
+	}
+}
+
+func TestDeleteExpiredFiresCallbackForEveryExpiredEntry(t *testing.T) {
+	c := newTestCache()
+	c.Items["expired1"] = models.Item[int]{Object: 1, Expiration: 1}
+	c.Items["expired2"] = models.Item[int]{Object: 2, Expiration: 1}
+	c.Items["fresh"] = models.Item[int]{Object: 3, Expiration: 0}
+
+	evicted := make(map[string]int)
+	c.OnEvicted(func(key string, value int) {
+		evicted[key] = value
+	})
+
+	c.DeleteExpired()
+
+	if len(evicted) != 2 {
+		t.Fatalf("expected 2 evicted entries, got %d: %v", len(evicted), evicted)
+	}
+	if evicted["expired1"] != 1 || evicted["expired2"] != 2 {
+		t.Fatalf("unexpected evicted values: %v", evicted)
+	}
+	if _, found := c.Items["fresh"]; !found {
+		t.Fatalf("expected \"fresh\" to survive DeleteExpired")
+	}
+}
+
+// TestSetDoesNotDeadlockOnCapacityEviction guards against a regression
+// where a capacity-triggered eviction fired onEvicted while still holding
+// c.mu, so any callback that itself touched the cache (even a read like
+// ItemCount) would deadlock on sync.RWMutex, which isn't reentrant.
+func TestSetDoesNotDeadlockOnCapacityEviction(t *testing.T) {
+	c := newTestCache()
+	c.MaxItems = 1
+	c.SetEvictionPolicy(eviction.NewLRU[string]())
+
+	c.OnEvicted(func(key string, value int) {
+		c.ItemCount()
+	})
+
+	c.Set("first", 1, globals.NoExpiration)
+
+	done := make(chan struct{})
+	go func() {
+		c.Set("second", 2, globals.NoExpiration)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Set deadlocked while firing onEvicted for a capacity eviction")
+	}
+
+	if _, found := c.Items["first"]; found {
+		t.Fatalf("expected \"first\" to have been evicted")
+	}
+	if _, found := c.Items["second"]; !found {
+		t.Fatalf("expected \"second\" to be present")
+	}
+}
+
+// TestSetEnforcesMaxItemsUnderTinyLFU guards against a regression where a
+// TinyLFU-rejected candidate was still written into c.Items and admitted
+// into the probationary segment, so a stream of one-off cold keys grew
+// the cache past MaxItems without bound instead of being turned away.
+func TestSetEnforcesMaxItemsUnderTinyLFU(t *testing.T) {
+	c := newTestCache()
+	c.MaxItems = 10
+	c.SetEvictionPolicy(eviction.NewTinyLFU[string](c.MaxItems))
+
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("cold-%d", i)
+		c.Set(key, i, globals.NoExpiration)
+
+		if count := c.ItemCount(); count > c.MaxItems {
+			t.Fatalf("ItemCount() = %d after inserting %q, want <= %d", count, key, c.MaxItems)
+		}
+	}
+}
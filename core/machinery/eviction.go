@@ -0,0 +1,25 @@
+package machinery
+
+// EvictionPolicy decides which key to give up once a bounded Cache is
+// full. Cache calls OnAccess on every Get and OnInsert on every new key it
+// adds, and OnDelete whenever a key leaves the cache by Delete or by TTL
+// expiry. A key the policy itself returns from Evict is dropped from the
+// policy's bookkeeping by Evict; Cache does not call OnDelete for it.
+// When Set needs to make room for candidate, the key it is
+// about to insert, it calls Evict(candidate), which returns the key to
+// give up, and false if there is nothing left to evict. Passing candidate
+// lets admission-filtered policies like TinyLFU judge the newcomer itself
+// against the existing victim, instead of only ever comparing keys already
+// in the cache. A policy that rejects candidate outright (TinyLFU's
+// admission filter) signals that by returning (candidate, true): the
+// caller must treat that as "do not evict anything, and do not admit
+// candidate either", not as an ordinary eviction of a same-named victim.
+//
+// Implementations live under core/eviction and satisfy this interface
+// structurally, without importing machinery.
+type EvictionPolicy[K comparable] interface {
+	OnAccess(key K)
+	OnInsert(key K)
+	OnDelete(key K)
+	Evict(candidate K) (key K, ok bool)
+}
@@ -5,13 +5,19 @@ import (
 )
 
 // GarbageCollector provides the buleprint for garbage collector
-type GarbageCollector struct {
+type GarbageCollector[K comparable, V any] struct {
 	Interval time.Duration
 	Stop     chan bool
 }
 
+// expirer is implemented by anything the garbage collector can sweep for
+// expired items, namely Cache[K, V] and ShardedCache[K, V].
+type expirer interface {
+	DeleteExpired()
+}
+
 // Run the garbage collector to clean up expired items from the cache.
-func (gc *GarbageCollector) Run(c *Cache) {
+func (gc *GarbageCollector[K, V]) Run(c expirer) {
 	ticker := time.NewTicker(gc.Interval)
 
 	for {
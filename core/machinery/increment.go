@@ -0,0 +1,306 @@
+package machinery
+
+import (
+	"fmt"
+	"time"
+)
+
+// Increment an item of type int, int8, int16, int32, int64, uint, uint8,
+// uint16, uint32, uint64, uintptr, float32, or float64 by n. Returns an
+// error if the item's value is not one of these types, or if the key
+// doesn't exist or has expired. If there is no error, the value is
+// incremented by n, the item's Expiration is left untouched, and the new
+// value is stored back in the cache.
+func (c *Cache[K, V]) Increment(key K, n int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, found := c.Items[key]
+	if !found || item.Expired() {
+		return fmt.Errorf("Item %v not found", key)
+	}
+
+	if c.evictionPolicy != nil {
+		c.evictionPolicy.OnAccess(key)
+	}
+
+	switch value := any(item.Object).(type) {
+	case int:
+		item.Object = any(value + int(n)).(V)
+	case int8:
+		item.Object = any(value + int8(n)).(V)
+	case int16:
+		item.Object = any(value + int16(n)).(V)
+	case int32:
+		item.Object = any(value + int32(n)).(V)
+	case int64:
+		item.Object = any(value + n).(V)
+	case uint:
+		item.Object = any(value + uint(n)).(V)
+	case uint8:
+		item.Object = any(value + uint8(n)).(V)
+	case uint16:
+		item.Object = any(value + uint16(n)).(V)
+	case uint32:
+		item.Object = any(value + uint32(n)).(V)
+	case uint64:
+		item.Object = any(value + uint64(n)).(V)
+	case uintptr:
+		item.Object = any(value + uintptr(n)).(V)
+	case float32:
+		item.Object = any(value + float32(n)).(V)
+	case float64:
+		item.Object = any(value + float64(n)).(V)
+	default:
+		return fmt.Errorf("The value for %v is not an integer or a float", key)
+	}
+
+	c.Items[key] = item
+	return nil
+}
+
+// Decrement an item of type int, int8, int16, int32, int64, uint, uint8,
+// uint16, uint32, uint64, uintptr, float32, or float64 by n. Returns an
+// error if the item's value is not one of these types, or if the key
+// doesn't exist or has expired.
+func (c *Cache[K, V]) Decrement(key K, n int64) error {
+	return c.Increment(key, -n)
+}
+
+// IncrementInt increments an item of type int by n, returning the new
+// value, or an error if the item's value is not an int, or if the key
+// doesn't exist or has expired.
+func (c *Cache[K, V]) IncrementInt(key K, n int) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, found := c.Items[key]
+	if !found || item.Expired() {
+		return 0, fmt.Errorf("Item %v not found", key)
+	}
+
+	if c.evictionPolicy != nil {
+		c.evictionPolicy.OnAccess(key)
+	}
+
+	value, ok := any(item.Object).(int)
+	if !ok {
+		return 0, fmt.Errorf("The value for %v is not an int", key)
+	}
+
+	value += n
+	item.Object = any(value).(V)
+	c.Items[key] = item
+	return value, nil
+}
+
+// IncrementInt32 increments an item of type int32 by n, returning the new
+// value, or an error if the item's value is not an int32, or if the key
+// doesn't exist or has expired.
+func (c *Cache[K, V]) IncrementInt32(key K, n int32) (int32, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, found := c.Items[key]
+	if !found || item.Expired() {
+		return 0, fmt.Errorf("Item %v not found", key)
+	}
+
+	if c.evictionPolicy != nil {
+		c.evictionPolicy.OnAccess(key)
+	}
+
+	value, ok := any(item.Object).(int32)
+	if !ok {
+		return 0, fmt.Errorf("The value for %v is not an int32", key)
+	}
+
+	value += n
+	item.Object = any(value).(V)
+	c.Items[key] = item
+	return value, nil
+}
+
+// IncrementInt64 increments an item of type int64 by n, returning the new
+// value, or an error if the item's value is not an int64, or if the key
+// doesn't exist or has expired.
+func (c *Cache[K, V]) IncrementInt64(key K, n int64) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, found := c.Items[key]
+	if !found || item.Expired() {
+		return 0, fmt.Errorf("Item %v not found", key)
+	}
+
+	if c.evictionPolicy != nil {
+		c.evictionPolicy.OnAccess(key)
+	}
+
+	value, ok := any(item.Object).(int64)
+	if !ok {
+		return 0, fmt.Errorf("The value for %v is not an int64", key)
+	}
+
+	value += n
+	item.Object = any(value).(V)
+	c.Items[key] = item
+	return value, nil
+}
+
+// IncrementUint increments an item of type uint by n, returning the new
+// value, or an error if the item's value is not a uint, or if the key
+// doesn't exist or has expired.
+func (c *Cache[K, V]) IncrementUint(key K, n uint) (uint, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, found := c.Items[key]
+	if !found || item.Expired() {
+		return 0, fmt.Errorf("Item %v not found", key)
+	}
+
+	if c.evictionPolicy != nil {
+		c.evictionPolicy.OnAccess(key)
+	}
+
+	value, ok := any(item.Object).(uint)
+	if !ok {
+		return 0, fmt.Errorf("The value for %v is not a uint", key)
+	}
+
+	value += n
+	item.Object = any(value).(V)
+	c.Items[key] = item
+	return value, nil
+}
+
+// IncrementFloat32 increments an item of type float32 by n, returning the
+// new value, or an error if the item's value is not a float32, or if the
+// key doesn't exist or has expired.
+func (c *Cache[K, V]) IncrementFloat32(key K, n float32) (float32, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, found := c.Items[key]
+	if !found || item.Expired() {
+		return 0, fmt.Errorf("Item %v not found", key)
+	}
+
+	if c.evictionPolicy != nil {
+		c.evictionPolicy.OnAccess(key)
+	}
+
+	value, ok := any(item.Object).(float32)
+	if !ok {
+		return 0, fmt.Errorf("The value for %v is not a float32", key)
+	}
+
+	value += n
+	item.Object = any(value).(V)
+	c.Items[key] = item
+	return value, nil
+}
+
+// IncrementFloat64 increments an item of type float64 by n, returning the
+// new value, or an error if the item's value is not a float64, or if the
+// key doesn't exist or has expired.
+func (c *Cache[K, V]) IncrementFloat64(key K, n float64) (float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, found := c.Items[key]
+	if !found || item.Expired() {
+		return 0, fmt.Errorf("Item %v not found", key)
+	}
+
+	if c.evictionPolicy != nil {
+		c.evictionPolicy.OnAccess(key)
+	}
+
+	value, ok := any(item.Object).(float64)
+	if !ok {
+		return 0, fmt.Errorf("The value for %v is not a float64", key)
+	}
+
+	value += n
+	item.Object = any(value).(V)
+	c.Items[key] = item
+	return value, nil
+}
+
+// IncrementByDuration increments an item of type time.Duration by n,
+// returning the new value, or an error if the item's value is not a
+// time.Duration, or if the key doesn't exist or has expired.
+func (c *Cache[K, V]) IncrementByDuration(key K, n time.Duration) (time.Duration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, found := c.Items[key]
+	if !found || item.Expired() {
+		return 0, fmt.Errorf("Item %v not found", key)
+	}
+
+	if c.evictionPolicy != nil {
+		c.evictionPolicy.OnAccess(key)
+	}
+
+	value, ok := any(item.Object).(time.Duration)
+	if !ok {
+		return 0, fmt.Errorf("The value for %v is not a time.Duration", key)
+	}
+
+	value += n
+	item.Object = any(value).(V)
+	c.Items[key] = item
+	return value, nil
+}
+
+// DecrementInt decrements an item of type int by n, returning the new
+// value, or an error if the item's value is not an int, or if the key
+// doesn't exist or has expired.
+func (c *Cache[K, V]) DecrementInt(key K, n int) (int, error) {
+	return c.IncrementInt(key, -n)
+}
+
+// DecrementInt32 decrements an item of type int32 by n, returning the new
+// value, or an error if the item's value is not an int32, or if the key
+// doesn't exist or has expired.
+func (c *Cache[K, V]) DecrementInt32(key K, n int32) (int32, error) {
+	return c.IncrementInt32(key, -n)
+}
+
+// DecrementInt64 decrements an item of type int64 by n, returning the new
+// value, or an error if the item's value is not an int64, or if the key
+// doesn't exist or has expired.
+func (c *Cache[K, V]) DecrementInt64(key K, n int64) (int64, error) {
+	return c.IncrementInt64(key, -n)
+}
+
+// DecrementUint decrements an item of type uint by n, returning the new
+// value, or an error if the item's value is not a uint, or if the key
+// doesn't exist or has expired.
+func (c *Cache[K, V]) DecrementUint(key K, n uint) (uint, error) {
+	return c.IncrementUint(key, -n)
+}
+
+// DecrementFloat32 decrements an item of type float32 by n, returning the
+// new value, or an error if the item's value is not a float32, or if the
+// key doesn't exist or has expired.
+func (c *Cache[K, V]) DecrementFloat32(key K, n float32) (float32, error) {
+	return c.IncrementFloat32(key, -n)
+}
+
+// DecrementFloat64 decrements an item of type float64 by n, returning the
+// new value, or an error if the item's value is not a float64, or if the
+// key doesn't exist or has expired.
+func (c *Cache[K, V]) DecrementFloat64(key K, n float64) (float64, error) {
+	return c.IncrementFloat64(key, -n)
+}
+
+// DecrementByDuration decrements an item of type time.Duration by n,
+// returning the new value, or an error if the item's value is not a
+// time.Duration, or if the key doesn't exist or has expired.
+func (c *Cache[K, V]) DecrementByDuration(key K, n time.Duration) (time.Duration, error) {
+	return c.IncrementByDuration(key, -n)
+}
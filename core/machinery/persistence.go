@@ -0,0 +1,118 @@
+package machinery
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"../models"
+)
+
+// Register registers a concrete type with encoding/gob so that values of
+// that type can be stored as a models.Item's Object and later survive a
+// Save/Load round trip. It must be called once per concrete type before
+// Save or Load is used with caches holding values of that type, mirroring
+// the requirement gob itself places on interface{} values (needed when V
+// is itself interface{}, e.g. the legacy-compatible Cache[string, any]).
+func Register(value interface{}) {
+	gob.Register(value)
+}
+
+// Save writes the cache's items to w as a gob stream. It is safe for
+// concurrent use with the rest of the Cache API.
+func (c *Cache[K, V]) Save(w io.Writer) (err error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	defer func() {
+		if x := recover(); x != nil {
+			err = fmt.Errorf("error registering item types with gob library: %v", x)
+		}
+	}()
+
+	enc := gob.NewEncoder(w)
+	return enc.Encode(&c.Items)
+}
+
+// SaveFile saves the cache's items to the given file, creating it if it
+// doesn't exist and overwriting it if it does. The file is written
+// atomically: the items are first encoded to a temporary file in the same
+// directory, which is then renamed over fname so a crash or concurrent
+// read never observes a partially written file.
+func (c *Cache[K, V]) SaveFile(fname string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(fname), filepath.Base(fname)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	if err := c.Save(tmp); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), fname)
+}
+
+// Load decodes a gob stream written by Save and merges the items it
+// contains into the cache, keeping items already in the cache intact.
+// An item from r only overwrites an existing item of the same key if the
+// incoming item hasn't expired. Each new key is run through the same
+// admission path as Set, so a bounded cache's MaxItems and EvictionPolicy
+// are respected (and kept in sync) across a restore instead of only being
+// consulted on regular inserts.
+func (c *Cache[K, V]) Load(r io.Reader) error {
+	items := map[K]models.Item[V]{}
+	dec := gob.NewDecoder(r)
+
+	if err := dec.Decode(&items); err != nil {
+		return err
+	}
+
+	var evictedItems []keyAndValue[K, V]
+
+	c.mu.Lock()
+	for key, item := range items {
+		if item.Expired() {
+			continue
+		}
+
+		victimKey, victimValue, evicted, admit := c.evictIfNeeded(key)
+		if !admit {
+			continue
+		}
+
+		c.Items[key] = item
+		if c.evictionPolicy != nil {
+			c.evictionPolicy.OnInsert(key)
+		}
+		if evicted {
+			evictedItems = append(evictedItems, keyAndValue[K, V]{victimKey, victimValue})
+		}
+	}
+	c.mu.Unlock()
+
+	for _, value := range evictedItems {
+		c.onEvicted(value.key, value.value)
+	}
+
+	return nil
+}
+
+// LoadFile loads and merges a cache saved with SaveFile.
+func (c *Cache[K, V]) LoadFile(fname string) error {
+	f, err := os.Open(fname)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return c.Load(f)
+}
@@ -0,0 +1,151 @@
+package machinery
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"../eviction"
+	"../models"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	c := newTestCache()
+	c.Items["foo"] = models.Item[int]{Object: 7, Expiration: 0}
+	c.Items["num"] = models.Item[int]{Object: 42, Expiration: 0}
+
+	var buf bytes.Buffer
+	if err := c.Save(&buf); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	loaded := newTestCache()
+	if err := loaded.Load(&buf); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if v, found := loaded.Items["foo"]; !found || v.Object != 7 {
+		t.Fatalf("expected \"foo\" to be 7, got %v (found=%v)", v.Object, found)
+	}
+	if v, found := loaded.Items["num"]; !found || v.Object != 42 {
+		t.Fatalf("expected \"num\" to be 42, got %v (found=%v)", v.Object, found)
+	}
+}
+
+func TestLoadMergesOnIncomingExpiry(t *testing.T) {
+	c := newTestCache()
+	c.Items["stale"] = models.Item[int]{Object: 1, Expiration: 0}
+	c.Items["fresh"] = models.Item[int]{Object: 1, Expiration: 0}
+
+	incoming := newTestCache()
+	incoming.Items["stale"] = models.Item[int]{Object: 2, Expiration: 1} // already expired
+	incoming.Items["fresh"] = models.Item[int]{Object: 2, Expiration: 0} // never expires
+
+	var buf bytes.Buffer
+	if err := incoming.Save(&buf); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	if err := c.Load(&buf); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if v := c.Items["stale"].Object; v != 1 {
+		t.Fatalf("expected expired incoming item to leave \"stale\" as 1, got %v", v)
+	}
+	if v := c.Items["fresh"].Object; v != 2 {
+		t.Fatalf("expected unexpired incoming item to overwrite \"fresh\" with 2, got %v", v)
+	}
+}
+
+// TestLoadEnforcesMaxItems guards against a regression where Load merged
+// decoded items straight into c.Items without consulting MaxItems or the
+// eviction policy, so restoring a snapshot into a bounded cache could grow
+// it arbitrarily past MaxItems and leave the policy's bookkeeping unaware
+// of the restored keys.
+func TestLoadEnforcesMaxItems(t *testing.T) {
+	c := newTestCache()
+	c.MaxItems = 10
+	c.SetEvictionPolicy(eviction.NewLRU[string]())
+
+	items := map[string]models.Item[int]{}
+	for i := 0; i < 1000; i++ {
+		items[fmt.Sprintf("cold-%d", i)] = models.Item[int]{Object: i}
+	}
+
+	var buf bytes.Buffer
+	if err := (&Cache[string, int]{Items: items}).Save(&buf); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	if err := c.Load(&buf); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if count := c.ItemCount(); count > c.MaxItems {
+		t.Fatalf("ItemCount() = %d after Load, want <= %d", count, c.MaxItems)
+	}
+}
+
+func TestSaveFileLoadFileRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	fname := filepath.Join(dir, "cache.gob")
+
+	c := newTestCache()
+	c.Items["foo"] = models.Item[int]{Object: 7, Expiration: 0}
+
+	if err := c.SaveFile(fname); err != nil {
+		t.Fatalf("SaveFile returned error: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir returned error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected SaveFile to leave exactly one file behind, got %d", len(entries))
+	}
+
+	loaded := newTestCache()
+	if err := loaded.LoadFile(fname); err != nil {
+		t.Fatalf("LoadFile returned error: %v", err)
+	}
+
+	if v, found := loaded.Items["foo"]; !found || v.Object != 7 {
+		t.Fatalf("expected \"foo\" to be 7, got %v (found=%v)", v.Object, found)
+	}
+}
+
+func TestSaveFileOverwritesExistingFileAtomically(t *testing.T) {
+	dir := t.TempDir()
+	fname := filepath.Join(dir, "cache.gob")
+
+	if err := os.WriteFile(fname, []byte("not a gob stream"), 0o644); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+
+	c := newTestCache()
+	c.Items["foo"] = models.Item[int]{Object: 7, Expiration: 0}
+
+	if err := c.SaveFile(fname); err != nil {
+		t.Fatalf("SaveFile returned error: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir returned error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected SaveFile to leave exactly one file behind, got %d: %v", len(entries), entries)
+	}
+
+	loaded := newTestCache()
+	if err := loaded.LoadFile(fname); err != nil {
+		t.Fatalf("LoadFile returned error: %v", err)
+	}
+	if v, found := loaded.Items["foo"]; !found || v.Object != 7 {
+		t.Fatalf("expected \"foo\" to be 7, got %v (found=%v)", v.Object, found)
+	}
+}
@@ -0,0 +1,144 @@
+package machinery
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"../models"
+)
+
+// ShardedCache spreads its items across a fixed, power-of-two number of
+// independent Cache shards, each guarded by its own mutex. Routing a key to
+// a shard via a hash of its bytes means that readers and writers touching
+// different keys no longer contend on a single RWMutex, which is the
+// bottleneck a plain Cache hits under concurrent load.
+type ShardedCache[K comparable, V any] struct {
+	shards           []*Cache[K, V]
+	shardMask        uint32
+	GarbageCollector *GarbageCollector[K, V]
+}
+
+// djb2-free fnv-1a hash of the key's fmt.Sprintf("%v", ...) form, used to
+// pick a shard. Hashing the formatted key rather than requiring K to
+// expose its own byte representation keeps ShardedCache usable for any
+// comparable key type, not just string.
+func hashKey[K comparable](key K) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(fmt.Sprintf("%v", key)))
+	return h.Sum32()
+}
+
+func (sc *ShardedCache[K, V]) shard(key K) *Cache[K, V] {
+	return sc.shards[hashKey(key)&sc.shardMask]
+}
+
+// NewShardedCache creates a ShardedCache with the given number of shards,
+// rounded up to the next power of two, each an independent Cache with the
+// given default expiration.
+func NewShardedCache[K comparable, V any](defaultExpiration time.Duration, shards int) *ShardedCache[K, V] {
+	n := 1
+	for n < shards {
+		n <<= 1
+	}
+
+	sc := &ShardedCache[K, V]{
+		shards:    make([]*Cache[K, V], n),
+		shardMask: uint32(n - 1),
+	}
+
+	for i := 0; i < n; i++ {
+		sc.shards[i] = &Cache[K, V]{
+			DefaultExpiration: defaultExpiration,
+			Items:             map[K]models.Item[V]{},
+		}
+	}
+
+	return sc
+}
+
+// Set adds an item to the cache, replacing any existing item, in whichever
+// shard the key hashes to. If the duration is 0 (DefaultExpiration), the
+// cache's default expiration time is used. If it is -1 (NoExpiration), the
+// item never expires.
+func (sc *ShardedCache[K, V]) Set(key K, value V, duration time.Duration) {
+	sc.shard(key).Set(key, value, duration)
+}
+
+// Add an item to the cache only if an item doesn't already exist for the
+// given key, or if the existing item has expired. Returns an error
+// otherwise.
+func (sc *ShardedCache[K, V]) Add(key K, value V, duration time.Duration) error {
+	return sc.shard(key).Add(key, value, duration)
+}
+
+// Replace sets a new value for the cache key only if it already exists, and
+// the existing item hasn't expired. Returns an error otherwise.
+func (sc *ShardedCache[K, V]) Replace(key K, value V, duration time.Duration) error {
+	return sc.shard(key).Replace(key, value, duration)
+}
+
+// Get an item from the cache. Returns the item (or its zero value), and a
+// bool indicating whether the key was found.
+func (sc *ShardedCache[K, V]) Get(key K) (V, bool) {
+	return sc.shard(key).Get(key)
+}
+
+// GetWithExpiration returns an item and its expiration time from the cache.
+// It returns the item (or its zero value), the expiration time if one is
+// set and a bool indicating whether the key was found.
+func (sc *ShardedCache[K, V]) GetWithExpiration(key K) (V, time.Time, bool) {
+	return sc.shard(key).GetWithExpiration(key)
+}
+
+// Delete an item from the cache. Does nothing if the key is not in the
+// cache.
+func (sc *ShardedCache[K, V]) Delete(key K) {
+	sc.shard(key).Delete(key)
+}
+
+// OnEvicted sets a function that is called with the key and value when an
+// item is evicted from any shard.
+func (sc *ShardedCache[K, V]) OnEvicted(f func(K, V)) {
+	for _, c := range sc.shards {
+		c.OnEvicted(f)
+	}
+}
+
+// DeleteExpired deletes expired items from the cache, locking one shard at
+// a time so garbage collection of one shard never stalls readers or
+// writers on the others.
+func (sc *ShardedCache[K, V]) DeleteExpired() {
+	for _, c := range sc.shards {
+		c.DeleteExpired()
+	}
+}
+
+// ItemCount returns the count of all items in the cache, across all shards,
+// including expired items.
+func (sc *ShardedCache[K, V]) ItemCount() int {
+	count := 0
+	for _, c := range sc.shards {
+		count += c.ItemCount()
+	}
+	return count
+}
+
+// Flush removes all items from every shard.
+func (sc *ShardedCache[K, V]) Flush() {
+	for _, c := range sc.shards {
+		c.Flush()
+	}
+}
+
+// GetItems copies all unexpired items across every shard into a single new
+// map and returns it.
+func (sc *ShardedCache[K, V]) GetItems() map[K]models.Item[V] {
+	items := map[K]models.Item[V]{}
+	for _, c := range sc.shards {
+		for key, item := range c.GetItems() {
+			items[key] = item
+		}
+	}
+	return items
+}
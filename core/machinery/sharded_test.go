@@ -0,0 +1,83 @@
+package machinery
+
+import (
+	"testing"
+
+	"../globals"
+)
+
+func newTestShardedCache() *ShardedCache[string, int] {
+	return NewShardedCache[string, int](globals.NoExpiration, 8)
+}
+
+func TestShardedCacheSetGet(t *testing.T) {
+	sc := newTestShardedCache()
+
+	sc.Set("foo", 42, globals.NoExpiration)
+
+	value, found := sc.Get("foo")
+	if !found || value != 42 {
+		t.Fatalf("expected (42, true), got (%v, %v)", value, found)
+	}
+}
+
+func TestShardedCacheRoutesKeysToShardsConsistently(t *testing.T) {
+	sc := newTestShardedCache()
+
+	keys := []string{"foo", "bar", "baz", "qux", "quux"}
+	for i, key := range keys {
+		sc.Set(key, i, globals.NoExpiration)
+	}
+
+	for i, key := range keys {
+		value, found := sc.Get(key)
+		if !found || value != i {
+			t.Fatalf("expected (%d, true) for %q, got (%v, %v)", i, key, value, found)
+		}
+	}
+
+	if count := sc.ItemCount(); count != len(keys) {
+		t.Fatalf("expected ItemCount() = %d, got %d", len(keys), count)
+	}
+}
+
+func TestShardedCacheDelete(t *testing.T) {
+	sc := newTestShardedCache()
+	sc.Set("foo", 42, globals.NoExpiration)
+
+	sc.Delete("foo")
+
+	if _, found := sc.Get("foo"); found {
+		t.Fatalf("expected \"foo\" to have been deleted")
+	}
+}
+
+func TestShardedCacheOnEvictedFiresFromTheOwningShard(t *testing.T) {
+	sc := newTestShardedCache()
+	sc.Set("foo", 42, globals.NoExpiration)
+
+	var gotKey string
+	var gotValue int
+	sc.OnEvicted(func(key string, value int) {
+		gotKey = key
+		gotValue = value
+	})
+
+	sc.Delete("foo")
+
+	if gotKey != "foo" || gotValue != 42 {
+		t.Fatalf("expected callback with (\"foo\", 42), got (%q, %v)", gotKey, gotValue)
+	}
+}
+
+func TestShardedCacheFlush(t *testing.T) {
+	sc := newTestShardedCache()
+	sc.Set("foo", 42, globals.NoExpiration)
+	sc.Set("baz", 43, globals.NoExpiration)
+
+	sc.Flush()
+
+	if count := sc.ItemCount(); count != 0 {
+		t.Fatalf("expected ItemCount() = 0 after Flush, got %d", count)
+	}
+}
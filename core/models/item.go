@@ -2,15 +2,17 @@ package models
 
 import "time"
 
-// Item represents the struct of an item to be
-// stored in the cache
-type Item struct {
-	Object     interface{}
+// Item represents the struct of an item to be stored in the cache. It is
+// generic over the stored value type V so callers get compile-time type
+// safety and avoid the interface{} boxing (and the allocation that comes
+// with it) the original, non-generic Item paid on every Get.
+type Item[V any] struct {
+	Object     V
 	Expiration int64
 }
 
 // Expired returns true if the Item has expired
-func (item Item) Expired() bool {
+func (item Item[V]) Expired() bool {
 	if item.Expiration == 0 {
 		return false
 	}
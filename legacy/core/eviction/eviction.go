@@ -0,0 +1,5 @@
+// Package eviction re-exports the generic eviction policies from
+// core/eviction, instantiated for legacy.Cache's string keys, instead of
+// carrying a hand-maintained, generics-stripped copy of each policy's
+// logic that has to be kept in lock step by hand.
+package eviction
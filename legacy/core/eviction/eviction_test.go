@@ -0,0 +1,113 @@
+package eviction
+
+import "testing"
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	l := NewLRU()
+	l.OnInsert("a")
+	l.OnInsert("b")
+	l.OnInsert("c")
+	l.OnAccess("a") // "a" is now most recently used; "b" is the LRU tail
+
+	key, ok := l.Evict("d")
+	if !ok || key != "b" {
+		t.Fatalf("expected to evict \"b\", got (%q, %v)", key, ok)
+	}
+}
+
+func TestLRUEvictEmpty(t *testing.T) {
+	l := NewLRU()
+
+	if _, ok := l.Evict("a"); ok {
+		t.Fatalf("expected Evict on an empty LRU to report false")
+	}
+}
+
+func TestLRUOnDeleteRemovesKey(t *testing.T) {
+	l := NewLRU()
+	l.OnInsert("a")
+	l.OnInsert("b")
+	l.OnDelete("b")
+
+	key, ok := l.Evict("c")
+	if !ok || key != "a" {
+		t.Fatalf("expected to evict \"a\" after deleting \"b\", got (%q, %v)", key, ok)
+	}
+}
+
+func TestLFUEvictsLeastFrequentlyUsed(t *testing.T) {
+	l := NewLFU()
+	l.OnInsert("a")
+	l.OnInsert("b")
+	l.OnAccess("a")
+	l.OnAccess("a")
+
+	key, ok := l.Evict("c")
+	if !ok || key != "b" {
+		t.Fatalf("expected to evict \"b\" (lower frequency), got (%q, %v)", key, ok)
+	}
+}
+
+func TestLFUEvictEmpty(t *testing.T) {
+	l := NewLFU()
+
+	if _, ok := l.Evict("a"); ok {
+		t.Fatalf("expected Evict on an empty LFU to report false")
+	}
+}
+
+func TestLFUOnDeleteRemovesKey(t *testing.T) {
+	l := NewLFU()
+	l.OnInsert("a")
+	l.OnInsert("b")
+	l.OnDelete("a")
+
+	key, ok := l.Evict("c")
+	if !ok || key != "b" {
+		t.Fatalf("expected to evict \"b\" after deleting \"a\", got (%q, %v)", key, ok)
+	}
+}
+
+func TestTinyLFUAdmitsFrequentCandidateOverColdVictim(t *testing.T) {
+	policy := NewTinyLFU(8)
+
+	// "cold" sits in the probationary segment with a single observation.
+	policy.OnInsert("cold")
+
+	// Pump up "hotCandidate"'s sketch frequency without admitting it:
+	// OnAccess of a key the policy doesn't track only feeds the sketch.
+	for i := 0; i < 10; i++ {
+		policy.OnAccess("hotCandidate")
+	}
+
+	key, ok := policy.Evict("hotCandidate")
+	if !ok || key != "cold" {
+		t.Fatalf("expected a frequent candidate to evict the cold probationary victim \"cold\", got (%q, %v)", key, ok)
+	}
+}
+
+func TestTinyLFURejectsColdCandidateAgainstHotVictim(t *testing.T) {
+	policy := NewTinyLFU(8)
+
+	// "hot" sits in the probationary segment (a single OnInsert keeps it
+	// out of the protected segment) but has a high sketch frequency from
+	// repeated re-insertion, the same way a hot key that is Set again and
+	// again without ever being read would.
+	policy.OnInsert("hot")
+	for i := 0; i < 10; i++ {
+		policy.OnInsert("hot")
+	}
+
+	key, ok := policy.Evict("cold")
+	if !ok || key != "cold" {
+		t.Fatalf("expected TinyLFU to reject \"cold\" (returning it as its own victim), got (%q, %v)", key, ok)
+	}
+}
+
+func TestTinyLFUEvictEmpty(t *testing.T) {
+	policy := NewTinyLFU(4)
+
+	if _, ok := policy.Evict("a"); ok {
+		t.Fatalf("expected Evict on an empty TinyLFU to report false")
+	}
+}
@@ -0,0 +1,12 @@
+package eviction
+
+import genericeviction "../../../core/eviction"
+
+// LFU is a least-frequently-used eviction policy for legacy.Cache's
+// string keys. See core/eviction.LFU for the implementation.
+type LFU = genericeviction.LFU[string]
+
+// NewLFU returns a new, empty LFU policy.
+func NewLFU() *LFU {
+	return genericeviction.NewLFU[string]()
+}
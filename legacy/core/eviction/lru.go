@@ -0,0 +1,12 @@
+package eviction
+
+import genericeviction "../../../core/eviction"
+
+// LRU is a least-recently-used eviction policy for legacy.Cache's string
+// keys. See core/eviction.LRU for the implementation.
+type LRU = genericeviction.LRU[string]
+
+// NewLRU returns a new, empty LRU policy.
+func NewLRU() *LRU {
+	return genericeviction.NewLRU[string]()
+}
@@ -0,0 +1,14 @@
+package eviction
+
+import genericeviction "../../../core/eviction"
+
+// TinyLFU is an admission-filtered, segmented-LRU eviction policy for
+// legacy.Cache's string keys. See core/eviction.TinyLFU for the
+// implementation.
+type TinyLFU = genericeviction.TinyLFU[string]
+
+// NewTinyLFU returns a new TinyLFU policy sized for roughly capacity
+// items.
+func NewTinyLFU(capacity int) *TinyLFU {
+	return genericeviction.NewTinyLFU[string](capacity)
+}
@@ -0,0 +1,18 @@
+// Package globals re-exports core/globals's expiration duration constants
+// for legacy.Cache, so the two packages share a single definition instead
+// of a hand-maintained copy that can drift out of sync.
+package globals
+
+import genericglobals "../../../core/globals"
+
+const (
+	// NoExpiration set the default value to use
+	// with functions that take an expiration time.
+	NoExpiration = genericglobals.NoExpiration
+	// DefaultExpiration set the default value to use
+	// with functions that take an expiration time.
+	// Equivalant to passing in the same expiration
+	// duration as was given to New() or NewFrom()
+	// when the cache was created (e.g. 5 minutes.).
+	DefaultExpiration = genericglobals.DefaultExpiration
+)
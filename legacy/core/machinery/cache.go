@@ -0,0 +1,14 @@
+// Package machinery implements legacy.Cache as a thin alias around the
+// generic machinery.Cache[K, V] from core/machinery, instantiated
+// with string keys and interface{}-valued items, instead of a hand
+// maintained, generics-stripped copy of its logic that has to be kept in
+// lock step by hand. Every method on the generic Cache - Set, Get,
+// Increment/Decrement, Save/Load, and so on - comes along automatically
+// because Cache is a type alias, not a wrapper type.
+package machinery
+
+import genericmachinery "../../../core/machinery"
+
+// Cache is an alias for the generic Cache[string, interface{}], preserving
+// legacy's original, non-generic API.
+type Cache = genericmachinery.Cache[string, interface{}]
@@ -0,0 +1,7 @@
+package machinery
+
+import genericmachinery "../../../core/machinery"
+
+// EvictionPolicy is an alias for the generic EvictionPolicy[string]. See
+// core/machinery.EvictionPolicy for the interface it describes.
+type EvictionPolicy = genericmachinery.EvictionPolicy[string]
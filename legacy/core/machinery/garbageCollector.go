@@ -0,0 +1,7 @@
+package machinery
+
+import genericmachinery "../../../core/machinery"
+
+// GarbageCollector is an alias for the generic
+// GarbageCollector[string, interface{}].
+type GarbageCollector = genericmachinery.GarbageCollector[string, interface{}]
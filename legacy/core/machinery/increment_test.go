@@ -0,0 +1,89 @@
+package machinery
+
+import (
+	"testing"
+	"time"
+
+	"../models"
+)
+
+func TestIncrementMissingKey(t *testing.T) {
+	c := newTestCache()
+
+	if err := c.Increment("nope", 1); err == nil {
+		t.Fatalf("expected an error incrementing a missing key")
+	}
+}
+
+func TestIncrementExpiredKey(t *testing.T) {
+	c := newTestCache()
+	c.Items["foo"] = models.Item{Object: 1, Expiration: 1}
+
+	if err := c.Increment("foo", 1); err == nil {
+		t.Fatalf("expected an error incrementing an expired key")
+	}
+}
+
+func TestIncrementTypeMismatch(t *testing.T) {
+	c := newTestCache()
+	c.Items["foo"] = models.Item{Object: "not a number"}
+
+	if err := c.Increment("foo", 1); err == nil {
+		t.Fatalf("expected an error incrementing a non-numeric value")
+	}
+}
+
+func TestIncrementInt(t *testing.T) {
+	c := newTestCache()
+	c.Items["foo"] = models.Item{Object: 1}
+
+	value, err := c.IncrementInt("foo", 41)
+	if err != nil {
+		t.Fatalf("IncrementInt returned error: %v", err)
+	}
+	if value != 42 {
+		t.Fatalf("expected 42, got %d", value)
+	}
+	if c.Items["foo"].Object != 42 {
+		t.Fatalf("expected stored value to be 42, got %v", c.Items["foo"].Object)
+	}
+}
+
+func TestIncrementIntOverflowWraps(t *testing.T) {
+	c := newTestCache()
+	c.Items["foo"] = models.Item{Object: int32(2147483647)}
+
+	value, err := c.IncrementInt32("foo", 1)
+	if err != nil {
+		t.Fatalf("IncrementInt32 returned error: %v", err)
+	}
+	if value != -2147483648 {
+		t.Fatalf("expected int32 overflow to wrap to -2147483648, got %d", value)
+	}
+}
+
+func TestDecrementInt(t *testing.T) {
+	c := newTestCache()
+	c.Items["foo"] = models.Item{Object: 10}
+
+	value, err := c.DecrementInt("foo", 4)
+	if err != nil {
+		t.Fatalf("DecrementInt returned error: %v", err)
+	}
+	if value != 6 {
+		t.Fatalf("expected 6, got %d", value)
+	}
+}
+
+func TestIncrementPreservesExpiration(t *testing.T) {
+	c := newTestCache()
+	expiration := time.Now().Add(time.Hour).UnixNano()
+	c.Items["foo"] = models.Item{Object: 1, Expiration: expiration}
+
+	if err := c.Increment("foo", 1); err != nil {
+		t.Fatalf("Increment returned error: %v", err)
+	}
+	if c.Items["foo"].Expiration != expiration {
+		t.Fatalf("expected Expiration to be left untouched, got %d", c.Items["foo"].Expiration)
+	}
+}
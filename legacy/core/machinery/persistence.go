@@ -0,0 +1,11 @@
+package machinery
+
+import genericmachinery "../../../core/machinery"
+
+// Register registers a concrete type with encoding/gob so that values of
+// that type can be stored in the cache and survive a Save/Load round trip.
+// It must be called once per concrete type stored as an Item's Object
+// before Save or Load is used.
+func Register(value interface{}) {
+	genericmachinery.Register(value)
+}
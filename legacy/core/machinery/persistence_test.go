@@ -0,0 +1,120 @@
+package machinery
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"../models"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	c := newTestCache()
+	c.Items["foo"] = models.Item{Object: "bar", Expiration: 0}
+	c.Items["num"] = models.Item{Object: 42, Expiration: 0}
+
+	var buf bytes.Buffer
+	if err := c.Save(&buf); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	loaded := newTestCache()
+	if err := loaded.Load(&buf); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if v, found := loaded.Items["foo"]; !found || v.Object != "bar" {
+		t.Fatalf("expected \"foo\" to be \"bar\", got %v (found=%v)", v.Object, found)
+	}
+	if v, found := loaded.Items["num"]; !found || v.Object != 42 {
+		t.Fatalf("expected \"num\" to be 42, got %v (found=%v)", v.Object, found)
+	}
+}
+
+func TestLoadMergesOnIncomingExpiry(t *testing.T) {
+	c := newTestCache()
+	c.Items["stale"] = models.Item{Object: "old", Expiration: 0}
+	c.Items["fresh"] = models.Item{Object: "old", Expiration: 0}
+
+	incoming := newTestCache()
+	incoming.Items["stale"] = models.Item{Object: "new", Expiration: 1} // already expired
+	incoming.Items["fresh"] = models.Item{Object: "new", Expiration: 0} // never expires
+
+	var buf bytes.Buffer
+	if err := incoming.Save(&buf); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	if err := c.Load(&buf); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if v := c.Items["stale"].Object; v != "old" {
+		t.Fatalf("expected expired incoming item to leave \"stale\" as \"old\", got %v", v)
+	}
+	if v := c.Items["fresh"].Object; v != "new" {
+		t.Fatalf("expected unexpired incoming item to overwrite \"fresh\" with \"new\", got %v", v)
+	}
+}
+
+func TestSaveFileLoadFileRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	fname := filepath.Join(dir, "cache.gob")
+
+	c := newTestCache()
+	c.Items["foo"] = models.Item{Object: "bar", Expiration: 0}
+
+	if err := c.SaveFile(fname); err != nil {
+		t.Fatalf("SaveFile returned error: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir returned error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected SaveFile to leave exactly one file behind, got %d", len(entries))
+	}
+
+	loaded := newTestCache()
+	if err := loaded.LoadFile(fname); err != nil {
+		t.Fatalf("LoadFile returned error: %v", err)
+	}
+
+	if v, found := loaded.Items["foo"]; !found || v.Object != "bar" {
+		t.Fatalf("expected \"foo\" to be \"bar\", got %v (found=%v)", v.Object, found)
+	}
+}
+
+func TestSaveFileOverwritesExistingFileAtomically(t *testing.T) {
+	dir := t.TempDir()
+	fname := filepath.Join(dir, "cache.gob")
+
+	if err := os.WriteFile(fname, []byte("not a gob stream"), 0o644); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+
+	c := newTestCache()
+	c.Items["foo"] = models.Item{Object: "bar", Expiration: 0}
+
+	if err := c.SaveFile(fname); err != nil {
+		t.Fatalf("SaveFile returned error: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir returned error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected SaveFile to leave exactly one file behind, got %d: %v", len(entries), entries)
+	}
+
+	loaded := newTestCache()
+	if err := loaded.LoadFile(fname); err != nil {
+		t.Fatalf("LoadFile returned error: %v", err)
+	}
+	if v, found := loaded.Items["foo"]; !found || v.Object != "bar" {
+		t.Fatalf("expected \"foo\" to be \"bar\", got %v (found=%v)", v.Object, found)
+	}
+}
@@ -0,0 +1,18 @@
+package machinery
+
+import (
+	"time"
+
+	genericmachinery "../../../core/machinery"
+)
+
+// ShardedCache is an alias for the generic
+// ShardedCache[string, interface{}].
+type ShardedCache = genericmachinery.ShardedCache[string, interface{}]
+
+// NewShardedCache creates a ShardedCache with the given number of shards,
+// rounded up to the next power of two, each an independent Cache with the
+// given default expiration.
+func NewShardedCache(defaultExpiration time.Duration, shards int) *ShardedCache {
+	return genericmachinery.NewShardedCache[string, interface{}](defaultExpiration, shards)
+}
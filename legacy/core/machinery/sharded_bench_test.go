@@ -0,0 +1,55 @@
+package machinery
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"../globals"
+	"../models"
+)
+
+type getSetter interface {
+	Set(string, interface{}, time.Duration)
+	Get(string) (interface{}, bool)
+}
+
+func benchmarkCacheGetConcurrent(b *testing.B, c getSetter) {
+	keys := make([]string, 64)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("foo-%d", i)
+		c.Set(keys[i], "bar", globals.NoExpiration)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			c.Get(keys[i%len(keys)])
+			i++
+		}
+	})
+}
+
+// BenchmarkCacheGetConcurrent measures concurrent Get throughput on a plain
+// Cache, which guards all readers and writers with a single RWMutex.
+func BenchmarkCacheGetConcurrent(b *testing.B) {
+	c := &Cache{
+		DefaultExpiration: globals.NoExpiration,
+		Items:             map[string]models.Item{},
+	}
+	benchmarkCacheGetConcurrent(b, c)
+}
+
+// BenchmarkShardedCacheGetConcurrent measures concurrent Get throughput on a
+// ShardedCache at 8, 16 and 64 shards, to show how spreading keys across
+// independently-locked shards relieves the single-mutex bottleneck above.
+func BenchmarkShardedCacheGetConcurrent(b *testing.B) {
+	for _, shards := range []int{8, 16, 64} {
+		shards := shards
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			sc := NewShardedCache(globals.NoExpiration, shards)
+			benchmarkCacheGetConcurrent(b, sc)
+		})
+	}
+}
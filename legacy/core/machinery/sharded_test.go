@@ -0,0 +1,83 @@
+package machinery
+
+import (
+	"testing"
+
+	"../globals"
+)
+
+func newTestShardedCache() *ShardedCache {
+	return NewShardedCache(globals.NoExpiration, 8)
+}
+
+func TestShardedCacheSetGet(t *testing.T) {
+	sc := newTestShardedCache()
+
+	sc.Set("foo", "bar", globals.NoExpiration)
+
+	value, found := sc.Get("foo")
+	if !found || value != "bar" {
+		t.Fatalf("expected (\"bar\", true), got (%v, %v)", value, found)
+	}
+}
+
+func TestShardedCacheRoutesKeysToShardsConsistently(t *testing.T) {
+	sc := newTestShardedCache()
+
+	keys := []string{"foo", "bar", "baz", "qux", "quux"}
+	for i, key := range keys {
+		sc.Set(key, i, globals.NoExpiration)
+	}
+
+	for i, key := range keys {
+		value, found := sc.Get(key)
+		if !found || value != i {
+			t.Fatalf("expected (%d, true) for %q, got (%v, %v)", i, key, value, found)
+		}
+	}
+
+	if count := sc.ItemCount(); count != len(keys) {
+		t.Fatalf("expected ItemCount() = %d, got %d", len(keys), count)
+	}
+}
+
+func TestShardedCacheDelete(t *testing.T) {
+	sc := newTestShardedCache()
+	sc.Set("foo", "bar", globals.NoExpiration)
+
+	sc.Delete("foo")
+
+	if _, found := sc.Get("foo"); found {
+		t.Fatalf("expected \"foo\" to have been deleted")
+	}
+}
+
+func TestShardedCacheOnEvictedFiresFromTheOwningShard(t *testing.T) {
+	sc := newTestShardedCache()
+	sc.Set("foo", "bar", globals.NoExpiration)
+
+	var gotKey string
+	var gotValue interface{}
+	sc.OnEvicted(func(key string, value interface{}) {
+		gotKey = key
+		gotValue = value
+	})
+
+	sc.Delete("foo")
+
+	if gotKey != "foo" || gotValue != "bar" {
+		t.Fatalf("expected callback with (\"foo\", \"bar\"), got (%q, %v)", gotKey, gotValue)
+	}
+}
+
+func TestShardedCacheFlush(t *testing.T) {
+	sc := newTestShardedCache()
+	sc.Set("foo", "bar", globals.NoExpiration)
+	sc.Set("baz", "qux", globals.NoExpiration)
+
+	sc.Flush()
+
+	if count := sc.ItemCount(); count != 0 {
+		t.Fatalf("expected ItemCount() = 0 after Flush, got %d", count)
+	}
+}
@@ -0,0 +1,12 @@
+// Package models re-exports core/models.Item for legacy.Cache, so the two
+// packages share a single definition instead of a hand-maintained copy
+// that can drift out of sync.
+package models
+
+import genericmodels "../../../core/models"
+
+// Item represents the struct of an item to be stored in legacy.Cache. It
+// is an alias for the interface{}-valued instance of the generic
+// core/models.Item[V], so Expired and any other methods added to the
+// generic type come along automatically.
+type Item = genericmodels.Item[interface{}]
@@ -0,0 +1,155 @@
+// Package legacy preserves veloce's original, non-generic Cache API
+// (interface{}-valued items behind a single RWMutex) for callers that
+// can't yet move to the generic veloce.Cache[K, V] introduced in the top
+// level package. Cache and its supporting types under legacy/core are
+// aliases for the generic machinery.Cache[string, interface{}] and its
+// own supporting types, so the two APIs share one implementation and
+// can't drift out of sync with each other.
+package legacy
+
+import (
+	"runtime"
+	"time"
+
+	"./core/machinery"
+	"./core/models"
+)
+
+// Cache represents the in memory key-value store
+type Cache struct {
+	*machinery.Cache
+}
+
+// ShardedCache represents an in memory key-value store split across
+// multiple independent shards, for use under concurrent load where a
+// single Cache's RWMutex would become a bottleneck.
+type ShardedCache struct {
+	*machinery.ShardedCache
+}
+
+func stopGarbageCollector(c *machinery.Cache) {
+	c.GarbageCollector.Stop <- true
+}
+
+func runGarbageCollector(c *machinery.Cache, cleanUpInterval time.Duration) {
+	gc := &machinery.GarbageCollector{
+		Interval: cleanUpInterval,
+		Stop:     make(chan bool),
+	}
+	c.GarbageCollector = gc
+	go gc.Run(c)
+}
+
+func stopShardedGarbageCollector(sc *machinery.ShardedCache) {
+	sc.GarbageCollector.Stop <- true
+}
+
+func runShardedGarbageCollector(sc *machinery.ShardedCache, cleanUpInterval time.Duration) {
+	gc := &machinery.GarbageCollector{
+		Interval: cleanUpInterval,
+		Stop:     make(chan bool),
+	}
+	sc.GarbageCollector = gc
+	go gc.Run(sc)
+}
+
+func newCache(duration time.Duration, cacheItems map[string]models.Item) *machinery.Cache {
+	if duration == 0 {
+		duration = -1
+	}
+	c := &machinery.Cache{
+		DefaultExpiration: duration,
+		Items:             cacheItems,
+	}
+	return c
+}
+
+func newCacheWithGarbageCollector(duration time.Duration, cleanUpInterval time.Duration, cacheItems map[string]models.Item) *Cache {
+	c := newCache(duration, cacheItems)
+
+	// makesure gc goroutine doesn't clean C (Cache) once it is returned.
+	C := &Cache{c}
+
+	if cleanUpInterval > 0 {
+		runGarbageCollector(c, cleanUpInterval)
+		runtime.SetFinalizer(C, stopGarbageCollector)
+	}
+	return C
+}
+
+// New returns a new cache with a given expiration time duration. A garbage collector is
+// initialized with a given clean up inerval.
+// If the expiration duration is less than one the items in the cache never expire, and
+// must be deleted manually.
+// If the cleanup interval is less than one, expired items are not deleted from the cache
+// before calling c.DeleteExpired().
+func New(defaultExpiration time.Duration, cleanUpInterval time.Duration) *Cache {
+	items := make(map[string]models.Item)
+	return newCacheWithGarbageCollector(defaultExpiration, cleanUpInterval, items)
+}
+
+// NewFrom returns a new cache with a given expiration time duration, bootstrapped
+// from an existing map of items (for example one returned by GetItems on a
+// cache filled with Cache.Load or Cache.LoadFile) so callers can recover from downtime quickly instead of
+// starting from an empty cache. A garbage collector is initialized with a
+// given clean up inerval.
+// If the expiration duration is less than one the items in the cache never expire, and
+// must be deleted manually.
+// If the cleanup interval is less than one, expired items are not deleted from the cache
+// before calling c.DeleteExpired().
+func NewFrom(defaultExpiration time.Duration, cleanUpInterval time.Duration, items map[string]models.Item) *Cache {
+	return newCacheWithGarbageCollector(defaultExpiration, cleanUpInterval, items)
+}
+
+// NewForm is a deprecated alias for NewFrom, kept for backwards compatibility
+// with the original, misspelled name.
+//
+// Deprecated: use NewFrom instead.
+func NewForm(defaultExpiration time.Duration, cleanUpInterval time.Duration, items map[string]models.Item) *Cache {
+	return NewFrom(defaultExpiration, cleanUpInterval, items)
+}
+
+// NewWithEviction returns a new cache bounded to roughly maxItems entries.
+// Once the bound is reached, Set consults policy for a key to give up
+// before admitting a new one; see core/eviction for the built-in LRU, LFU,
+// and TinyLFU policies. A maxItems of 0 or a nil policy leaves the cache
+// unbounded, the same as New. A garbage collector is initialized with a
+// given clean up interval, the same way it behaves for New.
+func NewWithEviction(defaultExpiration time.Duration, cleanUpInterval time.Duration, maxItems int, policy machinery.EvictionPolicy) *Cache {
+	items := make(map[string]models.Item)
+	C := newCacheWithGarbageCollector(defaultExpiration, cleanUpInterval, items)
+	C.MaxItems = maxItems
+	C.SetEvictionPolicy(policy)
+	return C
+}
+
+// NewSharded returns a new sharded cache with a given expiration time duration,
+// split across the given number of shards (rounded up to the next power of
+// two). Spreading items across independent shards, each with its own lock,
+// avoids the single RWMutex bottleneck a plain Cache hits under concurrent
+// load. A garbage collector is initialized with a given clean up interval
+// and sweeps one shard at a time, the same way DefaultExpiration and
+// cleanUpInterval behave for New.
+func NewSharded(defaultExpiration time.Duration, cleanUpInterval time.Duration, shards int) *ShardedCache {
+	if defaultExpiration == 0 {
+		defaultExpiration = -1
+	}
+
+	sc := machinery.NewShardedCache(defaultExpiration, shards)
+	SC := &ShardedCache{sc}
+
+	if cleanUpInterval > 0 {
+		runShardedGarbageCollector(sc, cleanUpInterval)
+		runtime.SetFinalizer(SC, stopShardedGarbageCollector)
+	}
+
+	return SC
+}
+
+// Register registers a concrete type with encoding/gob so that values of
+// that type can be stored in the cache and survive a Save/Load round trip.
+// It must be called once per concrete type stored as an Item's Object
+// before Save or Load is used.
+func Register(value interface{}) {
+	machinery.Register(value)
+}
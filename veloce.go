@@ -8,17 +8,25 @@ import (
 	"./core/models"
 )
 
-// Cache represents the in memory key-value store
-type Cache struct {
-	*machinery.Cache
+// Cache represents the in memory key-value store, generic over a
+// comparable key type K and an arbitrary value type V.
+type Cache[K comparable, V any] struct {
+	*machinery.Cache[K, V]
 }
 
-func stopGarbageCollector(c *machinery.Cache) {
+// ShardedCache represents an in memory key-value store split across
+// multiple independent shards, for use under concurrent load where a
+// single Cache's RWMutex would become a bottleneck.
+type ShardedCache[K comparable, V any] struct {
+	*machinery.ShardedCache[K, V]
+}
+
+func stopGarbageCollector[K comparable, V any](c *machinery.Cache[K, V]) {
 	c.GarbageCollector.Stop <- true
 }
 
-func runGarbageCollector(c *machinery.Cache, cleanUpInterval time.Duration) {
-	gc := &machinery.GarbageCollector{
+func runGarbageCollector[K comparable, V any](c *machinery.Cache[K, V], cleanUpInterval time.Duration) {
+	gc := &machinery.GarbageCollector[K, V]{
 		Interval: cleanUpInterval,
 		Stop:     make(chan bool),
 	}
@@ -26,47 +34,132 @@ func runGarbageCollector(c *machinery.Cache, cleanUpInterval time.Duration) {
 	go gc.Run(c)
 }
 
-func newCache(duration time.Duration, cacheItems map[string]models.Item) *machinery.Cache {
+func newCache[K comparable, V any](duration time.Duration, cacheItems map[K]models.Item[V]) *machinery.Cache[K, V] {
 	if duration == 0 {
 		duration = -1
 	}
-	c := &machinery.Cache{
+	c := &machinery.Cache[K, V]{
 		DefaultExpiration: duration,
 		Items:             cacheItems,
 	}
 	return c
 }
 
-func newCacheWithGarbageCollector(duration time.Duration, cleanUpInterval time.Duration, cacheItems map[string]models.Item) *Cache {
+func newCacheWithGarbageCollector[K comparable, V any](duration time.Duration, cleanUpInterval time.Duration, cacheItems map[K]models.Item[V]) *Cache[K, V] {
 	c := newCache(duration, cacheItems)
 
 	// makesure gc goroutine doesn't clean C (Cache) once it is returned.
-	C := &Cache{c}
+	C := &Cache[K, V]{c}
 
 	if cleanUpInterval > 0 {
 		runGarbageCollector(c, cleanUpInterval)
-		runtime.SetFinalizer(C, stopGarbageCollector)
+		runtime.SetFinalizer(C, stopGarbageCollector[K, V])
 	}
 	return C
 }
 
-// New returns a new cache with a given expiration time duration. A garbage collector is
-// initialized with a given clean up inerval.
+func stopShardedGarbageCollector[K comparable, V any](sc *machinery.ShardedCache[K, V]) {
+	sc.GarbageCollector.Stop <- true
+}
+
+func runShardedGarbageCollector[K comparable, V any](sc *machinery.ShardedCache[K, V], cleanUpInterval time.Duration) {
+	gc := &machinery.GarbageCollector[K, V]{
+		Interval: cleanUpInterval,
+		Stop:     make(chan bool),
+	}
+	sc.GarbageCollector = gc
+	go gc.Run(sc)
+}
+
+// New returns a new cache, keyed by string, holding values of type V, with
+// a given expiration time duration. A garbage collector is initialized
+// with a given clean up inerval.
 // If the expiration duration is less than one the items in the cache never expire, and
 // must be deleted manually.
 // If the cleanup interval is less than one, expired items are not deleted from the cache
 // before calling c.DeleteExpired().
-func New(defaultExpiration time.Duration, cleanUpInterval time.Duration) *Cache {
-	items := make(map[string]models.Item)
+func New[V any](defaultExpiration time.Duration, cleanUpInterval time.Duration) *Cache[string, V] {
+	items := make(map[string]models.Item[V])
 	return newCacheWithGarbageCollector(defaultExpiration, cleanUpInterval, items)
 }
 
-// NewForm returns a new cache with a given expiration time duration. A garbage collector is
-// initialized with a given clean up inerval.
+// NewTyped returns a new cache, generic over both the key type K and the
+// value type V, with a given expiration time duration. A garbage collector
+// is initialized with a given clean up inerval.
 // If the expiration duration is less than one the items in the cache never expire, and
 // must be deleted manually.
 // If the cleanup interval is less than one, expired items are not deleted from the cache
 // before calling c.DeleteExpired().
-func NewForm(defaultExpiration time.Duration, cleanUpInterval time.Duration, items map[string]models.Item) *Cache {
+func NewTyped[K comparable, V any](defaultExpiration time.Duration, cleanUpInterval time.Duration) *Cache[K, V] {
+	items := make(map[K]models.Item[V])
 	return newCacheWithGarbageCollector(defaultExpiration, cleanUpInterval, items)
 }
+
+// NewBounded returns a new cache, keyed by string and holding values of
+// type V, bounded to roughly maxItems entries. Once the bound is reached,
+// Set consults policy for a key to give up before admitting a new one; see
+// core/eviction for the built-in LRU, LFU, and TinyLFU policies. A
+// maxItems of 0 or a nil policy leaves the cache unbounded, the same as
+// New. A garbage collector is initialized with a given clean up interval,
+// the same way it behaves for New.
+func NewBounded[V any](defaultExpiration time.Duration, cleanUpInterval time.Duration, maxItems int, policy machinery.EvictionPolicy[string]) *Cache[string, V] {
+	items := make(map[string]models.Item[V])
+	C := newCacheWithGarbageCollector(defaultExpiration, cleanUpInterval, items)
+	C.MaxItems = maxItems
+	C.SetEvictionPolicy(policy)
+	return C
+}
+
+// NewFrom returns a new cache, keyed by string and holding values of type
+// V, with a given expiration time duration, bootstrapped from an existing
+// map of items (for example one returned by GetItems on a cache filled with
+// Cache.Load or Cache.LoadFile) so callers can recover from downtime quickly instead of starting from an
+// empty cache. A garbage collector is initialized with a given clean up
+// inerval.
+// If the expiration duration is less than one the items in the cache never expire, and
+// must be deleted manually.
+// If the cleanup interval is less than one, expired items are not deleted from the cache
+// before calling c.DeleteExpired().
+func NewFrom[V any](defaultExpiration time.Duration, cleanUpInterval time.Duration, items map[string]models.Item[V]) *Cache[string, V] {
+	return newCacheWithGarbageCollector(defaultExpiration, cleanUpInterval, items)
+}
+
+// NewForm is a deprecated alias for NewFrom, kept for backwards compatibility
+// with the original, misspelled name.
+//
+// Deprecated: use NewFrom instead.
+func NewForm[V any](defaultExpiration time.Duration, cleanUpInterval time.Duration, items map[string]models.Item[V]) *Cache[string, V] {
+	return NewFrom(defaultExpiration, cleanUpInterval, items)
+}
+
+// Register registers a concrete type with encoding/gob so that values of
+// that type can be stored in the cache and survive a Save/Load round trip.
+// It must be called once per concrete type stored as an Item's Object
+// before Save or Load is used.
+func Register(value interface{}) {
+	machinery.Register(value)
+}
+
+// NewSharded returns a new sharded cache, keyed by string and holding
+// values of type V, with a given expiration time duration, split across
+// the given number of shards (rounded up to the next power of two).
+// Spreading items across independent shards, each with its own lock,
+// avoids the single RWMutex bottleneck a plain Cache hits under concurrent
+// load. A garbage collector is initialized with a given clean up interval
+// and sweeps one shard at a time, the same way DefaultExpiration and
+// cleanUpInterval behave for New.
+func NewSharded[V any](defaultExpiration time.Duration, cleanUpInterval time.Duration, shards int) *ShardedCache[string, V] {
+	if defaultExpiration == 0 {
+		defaultExpiration = -1
+	}
+
+	sc := machinery.NewShardedCache[string, V](defaultExpiration, shards)
+	SC := &ShardedCache[string, V]{sc}
+
+	if cleanUpInterval > 0 {
+		runShardedGarbageCollector(sc, cleanUpInterval)
+		runtime.SetFinalizer(SC, stopShardedGarbageCollector[string, V])
+	}
+
+	return SC
+}